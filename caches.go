@@ -0,0 +1,209 @@
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// cachesBackend is the set of operations ApplyRetention needs from the
+// cached content service. Caches reaches the service only through a
+// cachesBackend; factoring it out as an interface keeps retention logic
+// testable without a live backend.
+type cachesBackend interface {
+	listCachedContent(ctx context.Context) ([]*CachedContent, error)
+	deleteCachedContent(ctx context.Context, name string) error
+}
+
+// Caches is the client for the Gemini API's cached content service.
+type Caches struct {
+	backend cachesBackend
+}
+
+// newCaches returns a Caches backed by the given cachesBackend.
+func newCaches(backend cachesBackend) *Caches {
+	return &Caches{backend: backend}
+}
+
+// CacheRetentionPolicy describes which CachedContent resources to keep and
+// which to expire, modeled on the "keep last N snapshots per time bucket"
+// policies used by backup tools such as restic. Bucket membership is
+// computed by truncating each CachedContent's CreateTime to the bucket's
+// granularity, in the CreateTime's own location.
+type CacheRetentionPolicy struct {
+	// KeepLast keeps the KeepLast most recently created caches,
+	// regardless of which buckets they fall into.
+	KeepLast int `json:"keepLast,omitempty"`
+	// KeepHourly keeps the most recently created cache in each of the
+	// KeepHourly most recent hourly buckets that contain a cache.
+	KeepHourly int `json:"keepHourly,omitempty"`
+	// KeepDaily is the daily equivalent of KeepHourly.
+	KeepDaily int `json:"keepDaily,omitempty"`
+	// KeepWeekly is the weekly (ISO week) equivalent of KeepHourly.
+	KeepWeekly int `json:"keepWeekly,omitempty"`
+	// KeepMonthly is the monthly equivalent of KeepHourly.
+	KeepMonthly int `json:"keepMonthly,omitempty"`
+	// KeepYearly is the yearly equivalent of KeepHourly.
+	KeepYearly int `json:"keepYearly,omitempty"`
+	// KeepWithinDuration keeps every cache created within this duration
+	// of now, regardless of bucket or KeepLast.
+	KeepWithinDuration time.Duration `json:"keepWithinDuration,omitempty"`
+	// KeepTags keeps every cache whose DisplayName contains one of these
+	// tags, regardless of bucket or KeepLast.
+	KeepTags []string `json:"keepTags,omitempty"`
+	// DryRun, if true, causes ApplyRetention to compute the keep and
+	// delete sets without deleting anything. It is a call option, not part
+	// of the policy, and is not marshaled.
+	DryRun bool `json:"-"`
+}
+
+// CacheRetentionResult is the outcome of applying a CacheRetentionPolicy.
+type CacheRetentionResult struct {
+	// Kept is every CachedContent the policy decided to keep.
+	Kept []*CachedContent
+	// Deleted is every CachedContent the policy decided to expire. If the
+	// policy's DryRun was false, these have already been deleted.
+	Deleted []*CachedContent
+}
+
+// ApplyRetention lists the caches available through c, applies policy to
+// partition them into keep and delete sets, and deletes the latter unless
+// policy.DryRun is set.
+func (c *Caches) ApplyRetention(ctx context.Context, policy CacheRetentionPolicy) (*CacheRetentionResult, error) {
+	all, err := c.backend.listCachedContent(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("genai: ApplyRetention: listing cached content: %w", err)
+	}
+
+	sorted := make([]*CachedContent, len(all))
+	copy(sorted, all)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].CreateTime.After(sorted[j].CreateTime)
+	})
+
+	keep := map[string]bool{}
+	now := time.Now()
+	for _, cc := range sorted {
+		if policy.KeepWithinDuration > 0 && now.Sub(cc.CreateTime) <= policy.KeepWithinDuration {
+			keep[cc.Name] = true
+		}
+		if hasAnyTag(cc.DisplayName, policy.KeepTags) {
+			keep[cc.Name] = true
+		}
+	}
+	for i, cc := range sorted {
+		if policy.KeepLast > 0 && i < policy.KeepLast {
+			keep[cc.Name] = true
+		}
+	}
+	keepBucketed(sorted, policy.KeepHourly, keep, bucketHourly)
+	keepBucketed(sorted, policy.KeepDaily, keep, bucketDaily)
+	keepBucketed(sorted, policy.KeepWeekly, keep, bucketWeekly)
+	keepBucketed(sorted, policy.KeepMonthly, keep, bucketMonthly)
+	keepBucketed(sorted, policy.KeepYearly, keep, bucketYearly)
+
+	result := &CacheRetentionResult{}
+	for _, cc := range sorted {
+		if keep[cc.Name] {
+			result.Kept = append(result.Kept, cc)
+		} else {
+			result.Deleted = append(result.Deleted, cc)
+		}
+	}
+
+	if !policy.DryRun {
+		for _, cc := range result.Deleted {
+			if err := c.backend.deleteCachedContent(ctx, cc.Name); err != nil {
+				return result, fmt.Errorf("genai: ApplyRetention: deleting %s: %w", cc.Name, err)
+			}
+		}
+	}
+	return result, nil
+}
+
+func hasAnyTag(displayName string, tags []string) bool {
+	for _, tag := range tags {
+		if tag != "" && strings.Contains(displayName, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// bucketKeyFunc computes the retention bucket that t falls into.
+type bucketKeyFunc func(time.Time) string
+
+func bucketHourly(t time.Time) string { return t.Format("2006-01-02T15") }
+
+func bucketDaily(t time.Time) string { return t.Format("2006-01-02") }
+
+func bucketWeekly(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%04d-W%02d", year, week)
+}
+
+func bucketMonthly(t time.Time) string { return t.Format("2006-01") }
+
+func bucketYearly(t time.Time) string { return t.Format("2006") }
+
+// keepBucketed keeps the most recently created cache, from sorted (which
+// must be newest-first), in each of the first limit distinct buckets that
+// key produces.
+func keepBucketed(sorted []*CachedContent, limit int, keep map[string]bool, key bucketKeyFunc) {
+	if limit <= 0 {
+		return
+	}
+	seen := map[string]bool{}
+	for _, cc := range sorted {
+		b := key(cc.CreateTime)
+		if seen[b] {
+			continue
+		}
+		if len(seen) >= limit {
+			break
+		}
+		seen[b] = true
+		keep[cc.Name] = true
+	}
+}
+
+type cacheRetentionPolicyAlias CacheRetentionPolicy
+
+// MarshalJSON renders KeepWithinDuration using the protobuf JSON Duration
+// encoding (e.g. "3600s"), consistent with VideoMetadata's offsets.
+func (p CacheRetentionPolicy) MarshalJSON() ([]byte, error) {
+	aux := struct {
+		KeepWithinDuration string `json:"keepWithinDuration,omitempty"`
+		*cacheRetentionPolicyAlias
+	}{
+		cacheRetentionPolicyAlias: (*cacheRetentionPolicyAlias)(&p),
+	}
+	if p.KeepWithinDuration != 0 {
+		aux.KeepWithinDuration = formatProtoDuration(p.KeepWithinDuration)
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON implements the inverse of CacheRetentionPolicy.MarshalJSON.
+func (p *CacheRetentionPolicy) UnmarshalJSON(data []byte) error {
+	aux := struct {
+		KeepWithinDuration string `json:"keepWithinDuration"`
+		*cacheRetentionPolicyAlias
+	}{
+		cacheRetentionPolicyAlias: (*cacheRetentionPolicyAlias)(p),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if aux.KeepWithinDuration != "" {
+		d, err := parseProtoDuration(aux.KeepWithinDuration)
+		if err != nil {
+			return fmt.Errorf("genai: CacheRetentionPolicy.keepWithinDuration: %w", err)
+		}
+		p.KeepWithinDuration = d
+	}
+	return nil
+}
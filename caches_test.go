@@ -0,0 +1,150 @@
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"testing"
+	"time"
+)
+
+type fakeCachesBackend struct {
+	contents []*CachedContent
+	deleted  []string
+}
+
+func (f *fakeCachesBackend) listCachedContent(ctx context.Context) ([]*CachedContent, error) {
+	return f.contents, nil
+}
+
+func (f *fakeCachesBackend) deleteCachedContent(ctx context.Context, name string) error {
+	f.deleted = append(f.deleted, name)
+	return nil
+}
+
+func namesOf(ccs []*CachedContent) []string {
+	names := make([]string, len(ccs))
+	for i, cc := range ccs {
+		names[i] = cc.Name
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestCachesApplyRetentionKeepLast(t *testing.T) {
+	now := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	backend := &fakeCachesBackend{contents: []*CachedContent{
+		{Name: "a", CreateTime: now.Add(-3 * 24 * time.Hour)},
+		{Name: "b", CreateTime: now.Add(-2 * 24 * time.Hour)},
+		{Name: "c", CreateTime: now.Add(-1 * 24 * time.Hour)},
+	}}
+	c := newCaches(backend)
+	result, err := c.ApplyRetention(context.Background(), CacheRetentionPolicy{KeepLast: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := namesOf(result.Kept), []string{"c"}; !equalStrings(got, want) {
+		t.Errorf("Kept = %v, want %v", got, want)
+	}
+	if got, want := namesOf(result.Deleted), []string{"a", "b"}; !equalStrings(got, want) {
+		t.Errorf("Deleted = %v, want %v", got, want)
+	}
+	gotDeleted := append([]string{}, backend.deleted...)
+	sort.Strings(gotDeleted)
+	if want := []string{"a", "b"}; !equalStrings(gotDeleted, want) {
+		t.Errorf("backend deleted = %v, want %v", gotDeleted, want)
+	}
+}
+
+func TestCachesApplyRetentionDryRun(t *testing.T) {
+	now := time.Now()
+	backend := &fakeCachesBackend{contents: []*CachedContent{
+		{Name: "a", CreateTime: now.Add(-1 * time.Hour)},
+	}}
+	c := newCaches(backend)
+	result, err := c.ApplyRetention(context.Background(), CacheRetentionPolicy{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Deleted) != 1 {
+		t.Fatalf("Deleted = %v, want 1 entry", result.Deleted)
+	}
+	if len(backend.deleted) != 0 {
+		t.Errorf("backend.deleted = %v, want none (DryRun)", backend.deleted)
+	}
+}
+
+func TestCachesApplyRetentionKeepDaily(t *testing.T) {
+	day1 := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	day2 := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	backend := &fakeCachesBackend{contents: []*CachedContent{
+		{Name: "day1-early", CreateTime: day1},
+		{Name: "day1-late", CreateTime: day1.Add(2 * time.Hour)},
+		{Name: "day2", CreateTime: day2},
+	}}
+	c := newCaches(backend)
+	result, err := c.ApplyRetention(context.Background(), CacheRetentionPolicy{KeepDaily: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := namesOf(result.Kept), []string{"day1-late", "day2"}; !equalStrings(got, want) {
+		t.Errorf("Kept = %v, want %v", got, want)
+	}
+}
+
+func TestCachesApplyRetentionKeepTagsAndWithin(t *testing.T) {
+	now := time.Now()
+	backend := &fakeCachesBackend{contents: []*CachedContent{
+		{Name: "old-tagged", DisplayName: "nightly-backup", CreateTime: now.Add(-365 * 24 * time.Hour)},
+		{Name: "old-untagged", CreateTime: now.Add(-365 * 24 * time.Hour)},
+		{Name: "recent", CreateTime: now.Add(-time.Minute)},
+	}}
+	c := newCaches(backend)
+	result, err := c.ApplyRetention(context.Background(), CacheRetentionPolicy{
+		KeepTags:           []string{"nightly"},
+		KeepWithinDuration: time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := namesOf(result.Kept), []string{"old-tagged", "recent"}; !equalStrings(got, want) {
+		t.Errorf("Kept = %v, want %v", got, want)
+	}
+	if got, want := namesOf(result.Deleted), []string{"old-untagged"}; !equalStrings(got, want) {
+		t.Errorf("Deleted = %v, want %v", got, want)
+	}
+}
+
+func TestCacheRetentionPolicyMarshalJSON(t *testing.T) {
+	p := CacheRetentionPolicy{KeepLast: 3, KeepWithinDuration: time.Hour, DryRun: true}
+	got, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"keepWithinDuration":"3600s","keepLast":3}`
+	if string(got) != want {
+		t.Errorf("MarshalJSON() = %s, want %s", got, want)
+	}
+	var roundTrip CacheRetentionPolicy
+	if err := json.Unmarshal(got, &roundTrip); err != nil {
+		t.Fatal(err)
+	}
+	if roundTrip.KeepLast != p.KeepLast || roundTrip.KeepWithinDuration != p.KeepWithinDuration {
+		t.Errorf("round trip = %+v, want %+v", roundTrip, p)
+	}
+	if roundTrip.DryRun {
+		t.Errorf("round trip DryRun = true, want false (not marshaled)")
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
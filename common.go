@@ -0,0 +1,8 @@
+package genai
+
+// Ptr returns a pointer to the given value. It is a convenience for setting
+// optional fields, most of which are represented as pointers so that the
+// zero value can be distinguished from "not set".
+func Ptr[T any](t T) *T {
+	return &t
+}
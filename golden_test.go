@@ -0,0 +1,40 @@
+package genai
+
+import (
+	"testing"
+
+	"github.com/Dareh123/go-genai/internal/jsontest"
+)
+
+// goldenTypes provides the constructor TestGoldenJSON needs for every
+// exported genai type with a custom MarshalJSON or UnmarshalJSON method.
+// jsontest.Run discovers those types itself by inspecting this package's
+// syntax tree (see jsontest.DiscoverJSONTypes), so forgetting an entry here
+// — or its testdata/<Name>/ fixture — fails the test rather than silently
+// leaving the type uncovered.
+var goldenTypes = []jsontest.Type{
+	{Name: "Schema", New: func() any { return &Schema{} }},
+	{Name: "Citation", New: func() any { return &Citation{} }},
+	{Name: "TokensInfo", New: func() any { return &TokensInfo{} }},
+	{Name: "CreateCachedContentConfig", New: func() any { return &CreateCachedContentConfig{} }},
+	{Name: "UpdateCachedContentConfig", New: func() any { return &UpdateCachedContentConfig{} }},
+	{Name: "GenerateContentResponse", New: func() any { return &GenerateContentResponse{} }},
+	{Name: "TunedModelInfo", New: func() any { return &TunedModelInfo{} }},
+	{Name: "CachedContent", New: func() any { return &CachedContent{} }},
+	{Name: "VideoMetadata", New: func() any { return &VideoMetadata{} }},
+	{Name: "Checkpoint", New: func() any { return &Checkpoint{} }},
+	{Name: "File", New: func() any { return &File{} }},
+	{Name: "SlidingWindow", New: func() any { return &SlidingWindow{} }},
+	{Name: "ContextWindowCompressionConfig", New: func() any { return &ContextWindowCompressionConfig{} }},
+	{Name: "CacheRetentionPolicy", New: func() any { return &CacheRetentionPolicy{} }},
+}
+
+// TestGoldenJSON fails if any exported type in this package implementing
+// json.Marshaler or json.Unmarshaler is missing from goldenTypes, then
+// round-trips every fixture in testdata/<Type>/ through Unmarshal then
+// Marshal and compares the result to the matching <case>.expect.json golden
+// file. Run with -update to regenerate goldens after an intentional
+// wire-format change.
+func TestGoldenJSON(t *testing.T) {
+	jsontest.Run(t, ".", "testdata", goldenTypes)
+}
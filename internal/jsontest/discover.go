@@ -0,0 +1,65 @@
+package jsontest
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"sort"
+	"strings"
+)
+
+// DiscoverJSONTypes parses the Go source files directly in pkgDir (a single
+// package, non-recursive; _test.go files are skipped) and returns the
+// sorted names of every exported type with a MarshalJSON or UnmarshalJSON
+// method, found by inspecting the package's syntax tree rather than relying
+// on a hand-maintained list.
+func DiscoverJSONTypes(pkgDir string) ([]string, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, pkgDir, func(fi fs.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", pkgDir, err)
+	}
+
+	found := map[string]bool{}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Recv == nil || len(fn.Recv.List) != 1 {
+					continue
+				}
+				if fn.Name.Name != "MarshalJSON" && fn.Name.Name != "UnmarshalJSON" {
+					continue
+				}
+				name := receiverTypeName(fn.Recv.List[0].Type)
+				if name != "" && ast.IsExported(name) {
+					found[name] = true
+				}
+			}
+		}
+	}
+
+	names := make([]string, 0, len(found))
+	for name := range found {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// receiverTypeName returns the base type name of a method receiver
+// expression, e.g. "Schema" for both "Schema" and "*Schema".
+func receiverTypeName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return receiverTypeName(t.X)
+	case *ast.Ident:
+		return t.Name
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,141 @@
+// Package jsontest provides a golden-file harness for checking that a
+// type's json.Marshaler and json.Unmarshaler implementations round-trip
+// correctly.
+//
+// Callers register one Type per API type they want covered, then call Run
+// from a *testing.T. Run reads every non-golden fixture in
+// testdata/<Type.Name>/, unmarshals it into a fresh value produced by
+// Type.New, re-marshals it, and compares the result against the matching
+// testdata/<Type.Name>/<case>.expect.json golden file. Run with -update to
+// (re)write the golden files from the current Marshal output.
+package jsontest
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var update = flag.Bool("update", false, "update golden files in testdata")
+
+// Type describes one API type to include in the round-trip harness.
+type Type struct {
+	// Name identifies the type and names its testdata subdirectory, e.g.
+	// "Schema" for testdata/Schema/.
+	Name string
+	// New returns a fresh, zero-valued pointer to the type, e.g.
+	// func() any { return &genai.Schema{} }.
+	New func() any
+}
+
+// Run discovers every exported type in pkgDir with a MarshalJSON or
+// UnmarshalJSON method (see DiscoverJSONTypes) and fails the test if any of
+// them is missing from types, then, for every t in types, round-trips each
+// fixture in testdataDir/<t.Name>/ through Unmarshal then Marshal and
+// compares the result against the matching golden file. Every t must have
+// at least one fixture; Run fails the test for any that don't. Together,
+// these checks mean a contributor who adds a json.Marshaler/Unmarshaler
+// without registering a Type and a fixture for it gets a test failure
+// instead of silently uncovered wire format.
+func Run(t *testing.T, pkgDir, testdataDir string, types []Type) {
+	discovered, err := DiscoverJSONTypes(pkgDir)
+	if err != nil {
+		t.Fatalf("discovering JSON types in %s: %v", pkgDir, err)
+	}
+	registered := make(map[string]bool, len(types))
+	for _, typ := range types {
+		registered[typ.Name] = true
+	}
+	for _, name := range discovered {
+		if !registered[name] {
+			t.Errorf("%s implements MarshalJSON or UnmarshalJSON but has no entry in the golden test registry; add one (and a testdata/%s/ fixture)", name, name)
+		}
+	}
+
+	for _, typ := range types {
+		typ := typ
+		t.Run(typ.Name, func(t *testing.T) {
+			dir := filepath.Join(testdataDir, typ.Name)
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatalf("reading %s: %v (add a testdata/%s/<case>.json fixture)", dir, err, typ.Name)
+			}
+			cases := 0
+			for _, entry := range entries {
+				name := entry.Name()
+				if entry.IsDir() || !strings.HasSuffix(name, ".json") || strings.HasSuffix(name, ".expect.json") {
+					continue
+				}
+				cases++
+				caseName := strings.TrimSuffix(name, ".json")
+				t.Run(caseName, func(t *testing.T) {
+					runCase(t, dir, caseName, typ.New)
+				})
+			}
+			if cases == 0 {
+				t.Fatalf("no fixtures in %s; every registered type needs at least one testdata/%s/<case>.json", dir, typ.Name)
+			}
+		})
+	}
+}
+
+func runCase(t *testing.T, dir, caseName string, newZero func() any) {
+	inputPath := filepath.Join(dir, caseName+".json")
+	input, err := os.ReadFile(inputPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v := newZero()
+	if err := json.Unmarshal(input, v); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	remarshaled, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	got, err := indentJSON(remarshaled)
+	if err != nil {
+		t.Fatalf("indenting Marshal output: %v", err)
+	}
+
+	expectPath := filepath.Join(dir, caseName+".expect.json")
+	if *update {
+		if err := os.WriteFile(expectPath, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(expectPath)
+	if err != nil {
+		t.Fatalf("reading golden file: %v (run with -update to create it)", err)
+	}
+	var gotVal, wantVal any
+	if err := json.Unmarshal(got, &gotVal); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(want, &wantVal); err != nil {
+		t.Fatalf("unmarshaling golden file %s: %v", expectPath, err)
+	}
+	if diff := cmp.Diff(wantVal, gotVal); diff != "" {
+		t.Errorf("round trip of %s mismatches golden file (-want +got):\n%s", inputPath, diff)
+	}
+}
+
+func indentJSON(data []byte) ([]byte, error) {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
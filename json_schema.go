@@ -0,0 +1,434 @@
+package genai
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// jsonSchemaDraft202012 is the $schema URI for JSON Schema Draft 2020-12,
+// written into the root document produced by Schema.MarshalJSONSchema.
+const jsonSchemaDraft202012 = "https://json-schema.org/draft/2020-12/schema"
+
+// MarshalJSONSchema renders s as a standard JSON Schema Draft 2020-12
+// document: integer bounds (MaxLength, MinLength, MaxItems, MinItems,
+// MaxProperties, MinProperties) are written as plain JSON numbers rather
+// than the quoted-string wire format Schema's json.Marshaler uses, and Type
+// is written in lower case (e.g. "object"), as a ["<type>", "null"] pair
+// when Nullable is set. A Schema with no Type gets no "type" keyword, even
+// when Nullable is set.
+//
+// A Schema graph that refers to itself through Items, Properties, or AnyOf
+// is rendered using "$defs" and "$ref" rather than being inlined, since
+// inlining a cycle would never terminate.
+//
+// MarshalJSONSchema is independent of Schema's json.Marshaler; the Gemini
+// API wire format is unaffected by this method's existence.
+func (s *Schema) MarshalJSONSchema() ([]byte, error) {
+	if s == nil {
+		return json.Marshal(nil)
+	}
+	e := &jsonSchemaExporter{
+		defNames:  map[*Schema]string{},
+		defBodies: map[string]map[string]any{},
+		visiting:  map[*Schema]bool{},
+	}
+	m := e.convert(s)
+	if len(e.defBodies) > 0 {
+		m["$defs"] = e.defBodies
+	}
+	m["$schema"] = jsonSchemaDraft202012
+	return json.Marshal(m)
+}
+
+// jsonSchemaExporter converts a Schema graph to JSON Schema, breaking cycles
+// by moving any Schema reachable from itself into "$defs" and replacing the
+// cyclic occurrence with a "$ref".
+type jsonSchemaExporter struct {
+	defNames  map[*Schema]string        // Schema already assigned a $defs name
+	defBodies map[string]map[string]any // $defs name -> converted body
+	visiting  map[*Schema]bool          // Schema currently being converted, i.e. an ancestor of the current call
+	counter   int
+}
+
+// convert returns s's JSON Schema representation: a "$ref" if s is an
+// ancestor of the current call (a cycle) or was already found to be one,
+// otherwise the inline object. If converting s's fields discovers that s is
+// itself part of a cycle, its body is moved into e.defBodies and a "$ref" is
+// returned instead.
+func (e *jsonSchemaExporter) convert(s *Schema) map[string]any {
+	if s == nil {
+		return nil
+	}
+	if name, ok := e.defNames[s]; ok {
+		return map[string]any{"$ref": "#/$defs/" + name}
+	}
+	if e.visiting[s] {
+		return map[string]any{"$ref": "#/$defs/" + e.assignDefName(s)}
+	}
+	e.visiting[s] = true
+	m := e.convertFields(s)
+	delete(e.visiting, s)
+
+	name, cyclic := e.defNames[s]
+	if !cyclic {
+		return m
+	}
+	e.defBodies[name] = m
+	return map[string]any{"$ref": "#/$defs/" + name}
+}
+
+func (e *jsonSchemaExporter) assignDefName(s *Schema) string {
+	if name, ok := e.defNames[s]; ok {
+		return name
+	}
+	e.counter++
+	name := fmt.Sprintf("schema%d", e.counter)
+	e.defNames[s] = name
+	return name
+}
+
+func (e *jsonSchemaExporter) convertFields(s *Schema) map[string]any {
+	m := map[string]any{}
+	if s.Type != "" && s.Type != TypeUnspecified {
+		typeName := jsonSchemaTypeName(s.Type)
+		if s.Nullable != nil && *s.Nullable {
+			m["type"] = []any{typeName, "null"}
+		} else {
+			m["type"] = typeName
+		}
+	}
+	if s.Title != "" {
+		m["title"] = s.Title
+	}
+	if s.Description != "" {
+		m["description"] = s.Description
+	}
+	if s.Format != "" {
+		m["format"] = s.Format
+	}
+	if s.Pattern != "" {
+		m["pattern"] = s.Pattern
+	}
+	if len(s.Enum) > 0 {
+		enum := make([]any, len(s.Enum))
+		for i, e := range s.Enum {
+			enum[i] = e
+		}
+		m["enum"] = enum
+	}
+	if s.Default != nil {
+		m["default"] = s.Default
+	}
+	if s.Example != nil {
+		m["examples"] = []any{s.Example}
+	}
+	if s.MinLength != nil {
+		m["minLength"] = *s.MinLength
+	}
+	if s.MaxLength != nil {
+		m["maxLength"] = *s.MaxLength
+	}
+	if s.Minimum != nil {
+		m["minimum"] = *s.Minimum
+	}
+	if s.Maximum != nil {
+		m["maximum"] = *s.Maximum
+	}
+	if s.MinItems != nil {
+		m["minItems"] = *s.MinItems
+	}
+	if s.MaxItems != nil {
+		m["maxItems"] = *s.MaxItems
+	}
+	if s.MinProperties != nil {
+		m["minProperties"] = *s.MinProperties
+	}
+	if s.MaxProperties != nil {
+		m["maxProperties"] = *s.MaxProperties
+	}
+	if s.Items != nil {
+		m["items"] = e.convert(s.Items)
+	}
+	if len(s.Properties) > 0 {
+		props := make(map[string]any, len(s.Properties))
+		for name, p := range s.Properties {
+			props[name] = e.convert(p)
+		}
+		m["properties"] = props
+	}
+	if len(s.PropertyOrdering) > 0 {
+		m["propertyOrdering"] = s.PropertyOrdering
+	}
+	if len(s.Required) > 0 {
+		m["required"] = s.Required
+	}
+	if len(s.AnyOf) > 0 {
+		anyOf := make([]any, len(s.AnyOf))
+		for i, sub := range s.AnyOf {
+			anyOf[i] = e.convert(sub)
+		}
+		m["anyOf"] = anyOf
+	}
+	return m
+}
+
+// SchemaFromJSONSchema converts a standard JSON Schema Draft 2020-12
+// document into a Schema.
+//
+// "$ref" is resolved against the document's top-level "$defs" (no other
+// base URI or remote reference is supported); a "$ref" that would require
+// Schema itself to contain a cycle is rejected, since Schema's other
+// methods (Validate, the Gemini wire-format json.Marshaler) assume an
+// acyclic tree and would never terminate on one.
+//
+// Other JSON Schema keywords with no Schema equivalent are approximated on
+// a best-effort basis: oneOf is folded into AnyOf (AnyOf's "at least one"
+// is a safe over-approximation of oneOf's "exactly one"); allOf has no safe
+// approximation ("must satisfy all" is not "at least one") and is rejected
+// rather than silently folded in; a schema-valued additionalProperties is
+// ignored (a boolean additionalProperties has no Schema equivalent either
+// and is also ignored); patternProperties is ignored; and only the first
+// prefixItems entry is used, as Items.
+func SchemaFromJSONSchema(data []byte) (*Schema, error) {
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("genai: SchemaFromJSONSchema: %w", err)
+	}
+	im := &schemaImporter{
+		defs:      map[string]map[string]any{},
+		resolved:  map[string]*Schema{},
+		resolving: map[string]bool{},
+	}
+	if defs, ok := m["$defs"].(map[string]any); ok {
+		for name, v := range defs {
+			def, ok := v.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("genai: SchemaFromJSONSchema: $defs.%s is not an object", name)
+			}
+			im.defs[name] = def
+		}
+	}
+	s, err := im.toSchema(m)
+	if err != nil {
+		return nil, fmt.Errorf("genai: SchemaFromJSONSchema: %w", err)
+	}
+	return s, nil
+}
+
+// schemaImporter resolves "$ref" against a document's top-level "$defs"
+// while converting it to a Schema, caching each def's conversion (so a def
+// referenced from multiple places is only converted once) and detecting
+// the self-referential refs that Schema cannot represent.
+type schemaImporter struct {
+	defs      map[string]map[string]any
+	resolved  map[string]*Schema
+	resolving map[string]bool
+}
+
+func (im *schemaImporter) resolveRef(ref string) (*Schema, error) {
+	name, ok := strings.CutPrefix(ref, "#/$defs/")
+	if !ok {
+		return nil, fmt.Errorf("unsupported $ref %q (only \"#/$defs/<name>\" is supported)", ref)
+	}
+	if s, ok := im.resolved[name]; ok {
+		return s, nil
+	}
+	if im.resolving[name] {
+		return nil, fmt.Errorf("$ref %q is self-referential; Schema cannot represent a recursive schema", ref)
+	}
+	def, ok := im.defs[name]
+	if !ok {
+		return nil, fmt.Errorf("$ref %q: no such definition in $defs", ref)
+	}
+	im.resolving[name] = true
+	s, err := im.toSchema(def)
+	delete(im.resolving, name)
+	if err != nil {
+		return nil, fmt.Errorf("$ref %q: %w", ref, err)
+	}
+	im.resolved[name] = s
+	return s, nil
+}
+
+func (im *schemaImporter) toSchema(m map[string]any) (*Schema, error) {
+	if ref, ok := m["$ref"].(string); ok {
+		return im.resolveRef(ref)
+	}
+	s := &Schema{}
+	switch t := m["type"].(type) {
+	case string:
+		s.Type = schemaTypeFromJSONSchemaName(t)
+	case []any:
+		for _, v := range t {
+			name, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("\"type\" array must contain only strings, got %T", v)
+			}
+			if name == "null" {
+				s.Nullable = Ptr(true)
+				continue
+			}
+			s.Type = schemaTypeFromJSONSchemaName(name)
+		}
+	case nil:
+		// Untyped schema; leave Type unset.
+	default:
+		return nil, fmt.Errorf("unsupported \"type\" value %v (%T)", t, t)
+	}
+	if title, ok := m["title"].(string); ok {
+		s.Title = title
+	}
+	if desc, ok := m["description"].(string); ok {
+		s.Description = desc
+	}
+	if format, ok := m["format"].(string); ok {
+		s.Format = format
+	}
+	if pattern, ok := m["pattern"].(string); ok {
+		s.Pattern = pattern
+	}
+	if enum, ok := m["enum"].([]any); ok {
+		for _, v := range enum {
+			if str, ok := v.(string); ok {
+				s.Enum = append(s.Enum, str)
+			}
+		}
+	}
+	if d, ok := m["default"]; ok {
+		s.Default = d
+	}
+	if examples, ok := m["examples"].([]any); ok && len(examples) > 0 {
+		s.Example = examples[0]
+	}
+
+	var err error
+	if s.MinLength, err = int64Field(m, "minLength"); err != nil {
+		return nil, err
+	}
+	if s.MaxLength, err = int64Field(m, "maxLength"); err != nil {
+		return nil, err
+	}
+	if s.MinItems, err = int64Field(m, "minItems"); err != nil {
+		return nil, err
+	}
+	if s.MaxItems, err = int64Field(m, "maxItems"); err != nil {
+		return nil, err
+	}
+	if s.MinProperties, err = int64Field(m, "minProperties"); err != nil {
+		return nil, err
+	}
+	if s.MaxProperties, err = int64Field(m, "maxProperties"); err != nil {
+		return nil, err
+	}
+	if s.Minimum, err = float64Field(m, "minimum"); err != nil {
+		return nil, err
+	}
+	if s.Maximum, err = float64Field(m, "maximum"); err != nil {
+		return nil, err
+	}
+
+	if items, ok := m["items"].(map[string]any); ok {
+		sub, err := im.toSchema(items)
+		if err != nil {
+			return nil, fmt.Errorf("items: %w", err)
+		}
+		s.Items = sub
+	} else if prefixItems, ok := m["prefixItems"].([]any); ok && len(prefixItems) > 0 {
+		first, ok := prefixItems[0].(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("prefixItems[0] is not an object")
+		}
+		sub, err := im.toSchema(first)
+		if err != nil {
+			return nil, fmt.Errorf("prefixItems[0]: %w", err)
+		}
+		s.Items = sub
+	}
+
+	if props, ok := m["properties"].(map[string]any); ok {
+		s.Properties = make(map[string]*Schema, len(props))
+		for name, v := range props {
+			pm, ok := v.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("properties.%s is not an object", name)
+			}
+			sub, err := im.toSchema(pm)
+			if err != nil {
+				return nil, fmt.Errorf("properties.%s: %w", name, err)
+			}
+			s.Properties[name] = sub
+		}
+	}
+
+	if required, ok := m["required"].([]any); ok {
+		for _, v := range required {
+			if str, ok := v.(string); ok {
+				s.Required = append(s.Required, str)
+			}
+		}
+	}
+	if ordering, ok := m["propertyOrdering"].([]any); ok {
+		for _, v := range ordering {
+			if str, ok := v.(string); ok {
+				s.PropertyOrdering = append(s.PropertyOrdering, str)
+			}
+		}
+	}
+
+	if _, ok := m["allOf"]; ok {
+		return nil, fmt.Errorf("allOf is not supported (Schema has no representation for \"must satisfy all\" semantics)")
+	}
+	for _, key := range []string{"anyOf", "oneOf"} {
+		list, ok := m[key].([]any)
+		if !ok {
+			continue
+		}
+		for i, v := range list {
+			sm, ok := v.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("%s[%d] is not an object", key, i)
+			}
+			sub, err := im.toSchema(sm)
+			if err != nil {
+				return nil, fmt.Errorf("%s[%d]: %w", key, i, err)
+			}
+			s.AnyOf = append(s.AnyOf, sub)
+		}
+	}
+
+	return s, nil
+}
+
+func int64Field(m map[string]any, key string) (*int64, error) {
+	v, ok := m[key]
+	if !ok {
+		return nil, nil
+	}
+	f, ok := v.(float64)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a number", key)
+	}
+	n := int64(f)
+	return &n, nil
+}
+
+func float64Field(m map[string]any, key string) (*float64, error) {
+	v, ok := m[key]
+	if !ok {
+		return nil, nil
+	}
+	f, ok := v.(float64)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a number", key)
+	}
+	return &f, nil
+}
+
+func jsonSchemaTypeName(t Type) string {
+	return strings.ToLower(string(t))
+}
+
+func schemaTypeFromJSONSchemaName(name string) Type {
+	return Type(strings.ToUpper(name))
+}
@@ -0,0 +1,206 @@
+package genai
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSchemaMarshalJSONSchema(t *testing.T) {
+	s := &Schema{
+		Type:        TypeObject,
+		Description: "a person",
+		Required:    []string{"name"},
+		Properties: map[string]*Schema{
+			"name": {Type: TypeString, MinLength: Ptr[int64](1)},
+			"age":  {Type: TypeInteger, Minimum: Ptr[float64](0), Nullable: Ptr(true)},
+		},
+	}
+	data, err := s.MarshalJSONSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["$schema"] != jsonSchemaDraft202012 {
+		t.Errorf("$schema = %v, want %v", got["$schema"], jsonSchemaDraft202012)
+	}
+	if got["type"] != "object" {
+		t.Errorf(`type = %v, want "object"`, got["type"])
+	}
+	props, ok := got["properties"].(map[string]any)
+	if !ok {
+		t.Fatalf("properties is not an object: %v", got["properties"])
+	}
+	name, ok := props["name"].(map[string]any)
+	if !ok {
+		t.Fatalf("properties.name is not an object: %v", props["name"])
+	}
+	if n, ok := name["minLength"].(float64); !ok || n != 1 {
+		t.Errorf("properties.name.minLength = %v, want 1 (plain number)", name["minLength"])
+	}
+	age, ok := props["age"].(map[string]any)
+	if !ok {
+		t.Fatalf("properties.age is not an object: %v", props["age"])
+	}
+	ageType, ok := age["type"].([]any)
+	if !ok || len(ageType) != 2 || ageType[0] != "integer" || ageType[1] != "null" {
+		t.Errorf(`properties.age.type = %v, want ["integer","null"]`, age["type"])
+	}
+}
+
+func TestSchemaFromJSONSchema(t *testing.T) {
+	doc := `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"required": ["name"],
+		"properties": {
+			"name": {"type": "string", "minLength": 1},
+			"tags": {"type": "array", "items": {"type": "string"}, "maxItems": 5},
+			"age": {"type": ["integer", "null"], "minimum": 0}
+		}
+	}`
+	s, err := SchemaFromJSONSchema([]byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Type != TypeObject {
+		t.Errorf("Type = %v, want OBJECT", s.Type)
+	}
+	if len(s.Required) != 1 || s.Required[0] != "name" {
+		t.Errorf("Required = %v, want [name]", s.Required)
+	}
+	name := s.Properties["name"]
+	if name == nil || name.Type != TypeString || name.MinLength == nil || *name.MinLength != 1 {
+		t.Errorf("Properties[name] = %+v, want STRING with MinLength 1", name)
+	}
+	tags := s.Properties["tags"]
+	if tags == nil || tags.Type != TypeArray || tags.Items == nil || tags.Items.Type != TypeString || tags.MaxItems == nil || *tags.MaxItems != 5 {
+		t.Errorf("Properties[tags] = %+v, want ARRAY of STRING with MaxItems 5", tags)
+	}
+	age := s.Properties["age"]
+	if age == nil || age.Type != TypeInteger || age.Nullable == nil || !*age.Nullable || age.Minimum == nil || *age.Minimum != 0 {
+		t.Errorf("Properties[age] = %+v, want nullable INTEGER with Minimum 0", age)
+	}
+}
+
+func TestSchemaJSONSchemaFullRoundTrip(t *testing.T) {
+	original := &Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"x": {Type: TypeNumber, Minimum: Ptr[float64](-1), Maximum: Ptr[float64](1)},
+		},
+		Required: []string{"x"},
+	}
+	data, err := original.MarshalJSONSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	roundTrip, err := SchemaFromJSONSchema(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if roundTrip.Type != original.Type {
+		t.Errorf("Type = %v, want %v", roundTrip.Type, original.Type)
+	}
+	x := roundTrip.Properties["x"]
+	want := original.Properties["x"]
+	if x == nil || x.Type != want.Type || *x.Minimum != *want.Minimum || *x.Maximum != *want.Maximum {
+		t.Errorf("Properties[x] = %+v, want %+v", x, want)
+	}
+}
+
+func TestSchemaFromJSONSchemaInvalid(t *testing.T) {
+	if _, err := SchemaFromJSONSchema([]byte(`{`)); err == nil {
+		t.Error("SchemaFromJSONSchema with malformed JSON = nil error, want error")
+	}
+	if _, err := SchemaFromJSONSchema([]byte(`{"type": 5}`)); err == nil {
+		t.Error(`SchemaFromJSONSchema with type: 5 = nil error, want error`)
+	}
+	if _, err := SchemaFromJSONSchema([]byte(`{"minLength": "abc"}`)); err == nil {
+		t.Error(`SchemaFromJSONSchema with minLength: "abc" = nil error, want error`)
+	}
+}
+
+func TestSchemaOneOfFoldsIntoAnyOf(t *testing.T) {
+	doc := `{"oneOf": [{"type": "string"}, {"type": "number"}]}`
+	s, err := SchemaFromJSONSchema([]byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.AnyOf) != 2 {
+		t.Fatalf("AnyOf = %+v, want 2 entries", s.AnyOf)
+	}
+}
+
+func TestSchemaFromJSONSchemaAllOfUnsupported(t *testing.T) {
+	doc := `{"allOf": [{"type": "number"}]}`
+	if _, err := SchemaFromJSONSchema([]byte(doc)); err == nil {
+		t.Error("SchemaFromJSONSchema with allOf = nil error, want error (allOf has no safe AnyOf approximation)")
+	}
+}
+
+func TestSchemaFromJSONSchemaSharedRef(t *testing.T) {
+	doc := `{
+		"$defs": {"leaf": {"type": "string"}},
+		"type": "object",
+		"properties": {
+			"a": {"$ref": "#/$defs/leaf"},
+			"b": {"$ref": "#/$defs/leaf"}
+		}
+	}`
+	s, err := SchemaFromJSONSchema([]byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, b := s.Properties["a"], s.Properties["b"]
+	if a == nil || a.Type != TypeString || b == nil || b.Type != TypeString {
+		t.Fatalf("Properties = %+v, want both a and b resolved to STRING", s.Properties)
+	}
+}
+
+func TestSchemaFromJSONSchemaSelfRefRejected(t *testing.T) {
+	doc := `{
+		"$defs": {"node": {"type": "object", "properties": {"next": {"$ref": "#/$defs/node"}}}},
+		"$ref": "#/$defs/node"
+	}`
+	if _, err := SchemaFromJSONSchema([]byte(doc)); err == nil {
+		t.Error("SchemaFromJSONSchema with self-referential $ref = nil error, want error")
+	}
+}
+
+func TestSchemaFromJSONSchemaUnsupportedRef(t *testing.T) {
+	doc := `{"$ref": "https://example.com/other.json"}`
+	if _, err := SchemaFromJSONSchema([]byte(doc)); err == nil {
+		t.Error("SchemaFromJSONSchema with a non-#/$defs/ $ref = nil error, want error")
+	}
+}
+
+func TestSchemaMarshalJSONSchemaCyclic(t *testing.T) {
+	node := &Schema{Type: TypeObject}
+	node.Properties = map[string]*Schema{"next": node}
+
+	data, err := node.MarshalJSONSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := got["$defs"]; !ok {
+		t.Fatalf("MarshalJSONSchema() = %s, want a $defs entry for the self-referential node", data)
+	}
+	if _, ok := got["$ref"]; !ok {
+		t.Fatalf("MarshalJSONSchema() = %s, want a top-level $ref", data)
+	}
+
+	// Schema cannot represent an actual Go-level pointer cycle safely (see
+	// SchemaFromJSONSchema's doc comment), so importing this document back
+	// is expected to fail with a clear error rather than hang or silently
+	// produce a non-cyclic approximation.
+	if _, err := SchemaFromJSONSchema(data); err == nil {
+		t.Error("SchemaFromJSONSchema of a self-referential $defs entry = nil error, want error")
+	}
+}
@@ -0,0 +1,246 @@
+package genai
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// SchemaValidationError describes a single violation of a Schema found
+// while validating a value. Path is a JSON Pointer (RFC 6901), relative to
+// the root of the validated value, locating where the violation occurred,
+// e.g. "/candidates/0/content".
+type SchemaValidationError struct {
+	Path string
+	Err  error
+}
+
+func (e *SchemaValidationError) Error() string {
+	if e.Path == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Err)
+}
+
+func (e *SchemaValidationError) Unwrap() error { return e.Err }
+
+// SchemaValidationErrors collects every SchemaValidationError found during a
+// single call to Schema.Validate or Schema.ValidateJSON, so that callers can
+// report all violations at once instead of failing on the first one.
+type SchemaValidationErrors []*SchemaValidationError
+
+func (e SchemaValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Validate walks the schema tree rooted at s and checks that v satisfies
+// it: string length bounds, numeric bounds, array item count bounds,
+// object property count bounds, required properties, enum membership,
+// pattern matching, and recursive descent into Properties, Items and
+// AnyOf. v should be built from the same types that encoding/json produces
+// when unmarshaling into an any (e.g. map[string]any, []any, float64,
+// string, bool, nil).
+//
+// Validate returns nil if v satisfies s, or a non-nil SchemaValidationErrors
+// listing every violation found.
+func (s *Schema) Validate(v any) error {
+	if s == nil {
+		return nil
+	}
+	var errs SchemaValidationErrors
+	s.validate("", v, &errs)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// ValidateJSON unmarshals data and validates the result against s. It is a
+// convenience for validating a raw model response before trusting it.
+func (s *Schema) ValidateJSON(data []byte) error {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("genai: ValidateJSON: %w", err)
+	}
+	return s.Validate(v)
+}
+
+func (s *Schema) validate(path string, v any, errs *SchemaValidationErrors) {
+	if s == nil {
+		return
+	}
+	if v == nil {
+		if s.Nullable == nil || !*s.Nullable {
+			if s.Type != "" && s.Type != TypeNull {
+				s.fail(errs, path, fmt.Errorf("value is null but schema is not nullable"))
+			}
+		}
+		return
+	}
+
+	switch s.Type {
+	case TypeString:
+		s.validateString(path, v, errs)
+	case TypeNumber, TypeInteger:
+		s.validateNumber(path, v, errs)
+	case TypeBoolean:
+		if _, ok := v.(bool); !ok {
+			s.fail(errs, path, fmt.Errorf("want boolean, got %T", v))
+		}
+	case TypeArray:
+		s.validateArray(path, v, errs)
+	case TypeObject:
+		s.validateObject(path, v, errs)
+	}
+
+	if len(s.Enum) > 0 {
+		str, ok := v.(string)
+		if !ok || !containsString(s.Enum, str) {
+			s.fail(errs, path, fmt.Errorf("value %v is not one of %v", v, s.Enum))
+		}
+	}
+
+	if len(s.AnyOf) > 0 {
+		ok := false
+		for _, sub := range s.AnyOf {
+			var subErrs SchemaValidationErrors
+			sub.validate(path, v, &subErrs)
+			if len(subErrs) == 0 {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			s.fail(errs, path, fmt.Errorf("value does not match any schema in anyOf"))
+		}
+	}
+}
+
+func (s *Schema) validateString(path string, v any, errs *SchemaValidationErrors) {
+	str, ok := v.(string)
+	if !ok {
+		s.fail(errs, path, fmt.Errorf("want string, got %T", v))
+		return
+	}
+	if s.MinLength != nil && int64(len(str)) < *s.MinLength {
+		s.fail(errs, path, fmt.Errorf("length %d is less than minLength %d", len(str), *s.MinLength))
+	}
+	if s.MaxLength != nil && int64(len(str)) > *s.MaxLength {
+		s.fail(errs, path, fmt.Errorf("length %d is greater than maxLength %d", len(str), *s.MaxLength))
+	}
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			s.fail(errs, path, fmt.Errorf("invalid pattern %q: %w", s.Pattern, err))
+		} else if !re.MatchString(str) {
+			s.fail(errs, path, fmt.Errorf("value %q does not match pattern %q", str, s.Pattern))
+		}
+	}
+}
+
+func (s *Schema) validateNumber(path string, v any, errs *SchemaValidationErrors) {
+	num, ok := asFloat64(v)
+	if !ok {
+		s.fail(errs, path, fmt.Errorf("want number, got %T", v))
+		return
+	}
+	if s.Type == TypeInteger && num != math.Trunc(num) {
+		s.fail(errs, path, fmt.Errorf("value %v is not an integer", num))
+	}
+	if s.Minimum != nil && num < *s.Minimum {
+		s.fail(errs, path, fmt.Errorf("value %v is less than minimum %v", num, *s.Minimum))
+	}
+	if s.Maximum != nil && num > *s.Maximum {
+		s.fail(errs, path, fmt.Errorf("value %v is greater than maximum %v", num, *s.Maximum))
+	}
+}
+
+func (s *Schema) validateArray(path string, v any, errs *SchemaValidationErrors) {
+	arr, ok := v.([]any)
+	if !ok {
+		s.fail(errs, path, fmt.Errorf("want array, got %T", v))
+		return
+	}
+	if s.MinItems != nil && int64(len(arr)) < *s.MinItems {
+		s.fail(errs, path, fmt.Errorf("item count %d is less than minItems %d", len(arr), *s.MinItems))
+	}
+	if s.MaxItems != nil && int64(len(arr)) > *s.MaxItems {
+		s.fail(errs, path, fmt.Errorf("item count %d is greater than maxItems %d", len(arr), *s.MaxItems))
+	}
+	if s.Items != nil {
+		for i, elem := range arr {
+			s.Items.validate(fmt.Sprintf("%s/%d", path, i), elem, errs)
+		}
+	}
+}
+
+func (s *Schema) validateObject(path string, v any, errs *SchemaValidationErrors) {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		s.fail(errs, path, fmt.Errorf("want object, got %T", v))
+		return
+	}
+	if s.MinProperties != nil && int64(len(obj)) < *s.MinProperties {
+		s.fail(errs, path, fmt.Errorf("property count %d is less than minProperties %d", len(obj), *s.MinProperties))
+	}
+	if s.MaxProperties != nil && int64(len(obj)) > *s.MaxProperties {
+		s.fail(errs, path, fmt.Errorf("property count %d is greater than maxProperties %d", len(obj), *s.MaxProperties))
+	}
+	for _, name := range s.Required {
+		if _, ok := obj[name]; !ok {
+			s.fail(errs, path, fmt.Errorf("missing required property %q", name))
+		}
+	}
+	for name, propSchema := range s.Properties {
+		if val, ok := obj[name]; ok {
+			propSchema.validate(path+"/"+jsonPointerEscape(name), val, errs)
+		}
+	}
+}
+
+func (s *Schema) fail(errs *SchemaValidationErrors, path string, err error) {
+	*errs = append(*errs, &SchemaValidationError{Path: path, Err: err})
+}
+
+func containsString(list []string, v string) bool {
+	for _, s := range list {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// asFloat64 reports the numeric value of v, which is expected to be the
+// float64 produced by encoding/json, or a Go numeric type for values built
+// directly in code rather than unmarshaled.
+func asFloat64(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
+// jsonPointerEscape escapes a single JSON Pointer (RFC 6901) reference
+// token.
+func jsonPointerEscape(token string) string {
+	token = strings.ReplaceAll(token, "~", "~0")
+	token = strings.ReplaceAll(token, "/", "~1")
+	return token
+}
@@ -0,0 +1,166 @@
+package genai
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSchemaValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  *Schema
+		value   any
+		wantErr []string // substrings expected to appear in the error, in any order
+	}{
+		{
+			name:   "string ok",
+			schema: &Schema{Type: TypeString, MinLength: Ptr[int64](2), MaxLength: Ptr[int64](5)},
+			value:  "abc",
+		},
+		{
+			name:    "string too short",
+			schema:  &Schema{Type: TypeString, MinLength: Ptr[int64](2)},
+			value:   "a",
+			wantErr: []string{"minLength"},
+		},
+		{
+			name:    "string too long",
+			schema:  &Schema{Type: TypeString, MaxLength: Ptr[int64](2)},
+			value:   "abc",
+			wantErr: []string{"maxLength"},
+		},
+		{
+			name:    "string pattern mismatch",
+			schema:  &Schema{Type: TypeString, Pattern: `^[a-z]+$`},
+			value:   "ABC",
+			wantErr: []string{"pattern"},
+		},
+		{
+			name:    "string not in enum",
+			schema:  &Schema{Type: TypeString, Enum: []string{"a", "b"}},
+			value:   "c",
+			wantErr: []string{"not one of"},
+		},
+		{
+			name:   "number in range",
+			schema: &Schema{Type: TypeNumber, Minimum: Ptr[float64](0), Maximum: Ptr[float64](10)},
+			value:  5.0,
+		},
+		{
+			name:    "number below minimum",
+			schema:  &Schema{Type: TypeNumber, Minimum: Ptr[float64](0)},
+			value:   -1.0,
+			wantErr: []string{"minimum"},
+		},
+		{
+			name:    "integer with fraction",
+			schema:  &Schema{Type: TypeInteger},
+			value:   1.5,
+			wantErr: []string{"not an integer"},
+		},
+		{
+			name:    "array too many items",
+			schema:  &Schema{Type: TypeArray, MaxItems: Ptr[int64](1)},
+			value:   []any{1.0, 2.0},
+			wantErr: []string{"maxItems"},
+		},
+		{
+			name:   "array item validated",
+			schema: &Schema{Type: TypeArray, Items: &Schema{Type: TypeString}},
+			value:  []any{1.0},
+			wantErr: []string{
+				"/0",
+				"want string",
+			},
+		},
+		{
+			name: "object missing required",
+			schema: &Schema{
+				Type:     TypeObject,
+				Required: []string{"name"},
+			},
+			value:   map[string]any{},
+			wantErr: []string{"missing required property \"name\""},
+		},
+		{
+			name: "object property validated",
+			schema: &Schema{
+				Type: TypeObject,
+				Properties: map[string]*Schema{
+					"name": {Type: TypeString, MinLength: Ptr[int64](1)},
+				},
+			},
+			value:   map[string]any{"name": ""},
+			wantErr: []string{"/name", "minLength"},
+		},
+		{
+			name:   "anyOf matches one branch",
+			schema: &Schema{AnyOf: []*Schema{{Type: TypeString}, {Type: TypeNumber}}},
+			value:  5.0,
+		},
+		{
+			name:    "anyOf matches no branch",
+			schema:  &Schema{AnyOf: []*Schema{{Type: TypeString}, {Type: TypeBoolean}}},
+			value:   5.0,
+			wantErr: []string{"anyOf"},
+		},
+		{
+			name:   "null accepted when nullable",
+			schema: &Schema{Type: TypeString, Nullable: Ptr(true)},
+			value:  nil,
+		},
+		{
+			name:    "null rejected when not nullable",
+			schema:  &Schema{Type: TypeString},
+			value:   nil,
+			wantErr: []string{"null"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.schema.Validate(tt.value)
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Validate() = nil, want error containing %v", tt.wantErr)
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Validate() = %q, want substring %q", err.Error(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestSchemaValidateJSON(t *testing.T) {
+	s := &Schema{
+		Type:     TypeObject,
+		Required: []string{"name"},
+		Properties: map[string]*Schema{
+			"name": {Type: TypeString, MinLength: Ptr[int64](1)},
+			"age":  {Type: TypeInteger, Minimum: Ptr[float64](0)},
+		},
+	}
+	if err := s.ValidateJSON([]byte(`{"name": "Ada", "age": 30}`)); err != nil {
+		t.Fatalf("ValidateJSON() = %v, want nil", err)
+	}
+	err := s.ValidateJSON([]byte(`{"age": -1}`))
+	if err == nil {
+		t.Fatal("ValidateJSON() = nil, want error")
+	}
+	if !strings.Contains(err.Error(), "missing required property") {
+		t.Errorf("ValidateJSON() = %q, want it to mention the missing required property", err.Error())
+	}
+	if !strings.Contains(err.Error(), "minimum") {
+		t.Errorf("ValidateJSON() = %q, want it to mention the minimum violation", err.Error())
+	}
+
+	if err := s.ValidateJSON([]byte(`{`)); err == nil {
+		t.Fatal("ValidateJSON() with malformed JSON = nil, want error")
+	}
+}
@@ -0,0 +1,279 @@
+package genai
+
+import (
+	"time"
+
+	"cloud.google.com/go/civil"
+)
+
+// Type is the data type of a Schema or schema-typed value.
+type Type string
+
+const (
+	TypeUnspecified Type = "TYPE_UNSPECIFIED"
+	TypeString      Type = "STRING"
+	TypeNumber      Type = "NUMBER"
+	TypeInteger     Type = "INTEGER"
+	TypeBoolean     Type = "BOOLEAN"
+	TypeArray       Type = "ARRAY"
+	TypeObject      Type = "OBJECT"
+	TypeNull        Type = "NULL"
+)
+
+// Schema defines the structure and constraints of a value that the model
+// may produce or consume, such as a function parameter or a structured
+// response. It mirrors a useful subset of the OpenAPI 3.0 schema object.
+type Schema struct {
+	// AnyOf lists schemas, any one of which the value must satisfy.
+	AnyOf []*Schema `json:"anyOf,omitempty"`
+	// Default is the default value of the schema.
+	Default any `json:"default,omitempty"`
+	// Description is a human readable explanation of the value.
+	Description string `json:"description,omitempty"`
+	// Enum restricts a string value to one of the listed values.
+	Enum []string `json:"enum,omitempty"`
+	// Example is an example of the value.
+	Example any `json:"example,omitempty"`
+	// Format is the format of the data, depending on Type (e.g. "int64",
+	// "date-time").
+	Format string `json:"format,omitempty"`
+	// Items is the schema of the elements of an ARRAY type.
+	Items *Schema `json:"items,omitempty"`
+	// MaxItems is the maximum number of elements for an ARRAY type.
+	MaxItems *int64 `json:"maxItems,omitempty"`
+	// MaxLength is the maximum length for a STRING type.
+	MaxLength *int64 `json:"maxLength,omitempty"`
+	// MaxProperties is the maximum number of properties for an OBJECT type.
+	MaxProperties *int64 `json:"maxProperties,omitempty"`
+	// Maximum is the maximum value for a NUMBER or INTEGER type.
+	Maximum *float64 `json:"maximum,omitempty"`
+	// MinItems is the minimum number of elements for an ARRAY type.
+	MinItems *int64 `json:"minItems,omitempty"`
+	// MinLength is the minimum length for a STRING type.
+	MinLength *int64 `json:"minLength,omitempty"`
+	// MinProperties is the minimum number of properties for an OBJECT type.
+	MinProperties *int64 `json:"minProperties,omitempty"`
+	// Minimum is the minimum value for a NUMBER or INTEGER type.
+	Minimum *float64 `json:"minimum,omitempty"`
+	// Nullable indicates whether the value may be null.
+	Nullable *bool `json:"nullable,omitempty"`
+	// Pattern is a regular expression a STRING type value must match.
+	Pattern string `json:"pattern,omitempty"`
+	// Properties maps property name to its Schema for an OBJECT type.
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	// PropertyOrdering is the order in which Properties should be presented.
+	PropertyOrdering []string `json:"propertyOrdering,omitempty"`
+	// Required lists the names of properties that must be present for an
+	// OBJECT type.
+	Required []string `json:"required,omitempty"`
+	// Title is a human readable name for the schema.
+	Title string `json:"title,omitempty"`
+	// Type is the data type of the value.
+	Type Type `json:"type,omitempty"`
+}
+
+// Citation is a citation for a portion of generated content.
+type Citation struct {
+	// EndIndex is the end index, exclusive, of the cited content.
+	EndIndex int32 `json:"endIndex,omitempty"`
+	// License is the license of the cited source.
+	License string `json:"license,omitempty"`
+	// PublicationDate is the publication date of the cited source.
+	PublicationDate civil.Date `json:"publicationDate,omitempty"`
+	// StartIndex is the start index of the cited content.
+	StartIndex int32 `json:"startIndex,omitempty"`
+	// Title is the title of the cited source.
+	Title string `json:"title,omitempty"`
+	// URI is the URI of the cited source.
+	URI string `json:"uri,omitempty"`
+}
+
+// TokensInfo represents the tokens resulting from tokenizing text, or the
+// text resulting from detokenizing token ids.
+type TokensInfo struct {
+	// Role is the role of the content that the tokens are from.
+	Role string `json:"role,omitempty"`
+	// TokenIDs are the list of token ids.
+	TokenIDs []int64 `json:"tokenIds,omitempty"`
+	// Tokens are the list of raw bytes of the tokens.
+	Tokens [][]byte `json:"tokens,omitempty"`
+}
+
+// Content is the multi-part content of a message.
+type Content struct {
+	// Role is the producer of the content, e.g. "user" or "model".
+	Role string `json:"role,omitempty"`
+	// Parts are the ordered parts that make up the content.
+	Parts []*Part `json:"parts,omitempty"`
+}
+
+// Part is a single part of multi-part Content.
+type Part struct {
+	// Text is the text content of the part.
+	Text string `json:"text,omitempty"`
+}
+
+// FinishReason is why the model stopped generating tokens for a Candidate.
+// Unrecognized values are preserved verbatim rather than rejected, so that
+// clients built against an older version of this package can still read
+// the response.
+type FinishReason string
+
+// Candidate is a candidate response generated from the model.
+type Candidate struct {
+	// Content is the generated content.
+	Content *Content `json:"content,omitempty"`
+	// FinishReason is the reason the model stopped generating tokens.
+	FinishReason FinishReason `json:"finishReason,omitempty"`
+	// Index is the index of this candidate in the response.
+	Index int32 `json:"index,omitempty"`
+}
+
+// GenerateContentResponse is the response from a generate content request.
+type GenerateContentResponse struct {
+	// Candidates is the list of generated candidate responses.
+	Candidates []*Candidate `json:"candidates,omitempty"`
+	// ModelVersion is the version of the model used to generate the response.
+	ModelVersion string `json:"modelVersion,omitempty"`
+	// ResponseID identifies this response.
+	ResponseID string `json:"responseId,omitempty"`
+	// CreateTime is when the response was generated.
+	CreateTime time.Time `json:"createTime,omitempty"`
+}
+
+// CachedContent is content that has been preprocessed and can be reused in
+// subsequent requests.
+type CachedContent struct {
+	// Name is the resource name of the cached content.
+	Name string `json:"name,omitempty"`
+	// DisplayName is a human readable name for the cached content.
+	DisplayName string `json:"displayName,omitempty"`
+	// Model is the name of the model the cached content was created for.
+	Model string `json:"model,omitempty"`
+	// CreateTime is when the cached content was created.
+	CreateTime time.Time `json:"createTime,omitempty"`
+	// UpdateTime is when the cached content was last updated.
+	UpdateTime time.Time `json:"updateTime,omitempty"`
+	// ExpireTime is when the cached content will expire.
+	ExpireTime time.Time `json:"expireTime,omitempty"`
+}
+
+// CreateCachedContentConfig holds optional parameters for creating a
+// CachedContent.
+type CreateCachedContentConfig struct {
+	// DisplayName is a human readable name for the cached content.
+	DisplayName string `json:"displayName,omitempty"`
+	// Contents are the content to cache.
+	Contents []*Content `json:"contents,omitempty"`
+	// SystemInstruction is the system instruction to cache alongside Contents.
+	SystemInstruction *Content `json:"systemInstruction,omitempty"`
+	// TTL is the duration, e.g. "3600s", that the cached content should
+	// remain valid for. TTL and ExpireTime are mutually exclusive; if
+	// neither is set, the service default TTL applies.
+	TTL string `json:"ttl,omitempty"`
+	// ExpireTime is the exact time at which the cached content will expire.
+	// TTL and ExpireTime are mutually exclusive.
+	ExpireTime time.Time `json:"expireTime,omitempty"`
+}
+
+// UpdateCachedContentConfig holds optional parameters for updating a
+// CachedContent.
+type UpdateCachedContentConfig struct {
+	// TTL is the duration that the cached content should remain valid for.
+	TTL string `json:"ttl,omitempty"`
+	// ExpireTime is the exact time at which the cached content will expire.
+	ExpireTime time.Time `json:"expireTime,omitempty"`
+}
+
+// TunedModelInfo is information about a tuned model.
+type TunedModelInfo struct {
+	// BaseModel is the name of the base model that was tuned.
+	BaseModel string `json:"baseModel,omitempty"`
+	// CreateTime is when the tuned model was created.
+	CreateTime time.Time `json:"createTime,omitempty"`
+	// UpdateTime is when the tuned model was last updated.
+	UpdateTime time.Time `json:"updateTime,omitempty"`
+}
+
+// Checkpoint is a checkpoint produced while tuning a model.
+type Checkpoint struct {
+	// CheckpointID identifies the checkpoint.
+	CheckpointID string `json:"checkpointId,omitempty"`
+	// Epoch is the training epoch the checkpoint was taken at.
+	Epoch int64 `json:"epoch,omitempty"`
+	// Step is the training step the checkpoint was taken at.
+	Step int64 `json:"step,omitempty"`
+}
+
+// VideoMetadata describes metadata for a video Part, such as the portion of
+// the video to consider.
+type VideoMetadata struct {
+	// StartOffset is the start offset of the video clip.
+	StartOffset time.Duration `json:"startOffset,omitempty"`
+	// EndOffset is the end offset of the video clip.
+	EndOffset time.Duration `json:"endOffset,omitempty"`
+}
+
+// FileState is the processing state of a File.
+type FileState string
+
+// FileSource is how a File was created.
+type FileSource string
+
+// FileStatus is the error status of a File, if processing it failed.
+type FileStatus struct {
+	// Code is the status code, following google.rpc.Code conventions.
+	Code int32 `json:"code,omitempty"`
+	// Message is a developer-facing error message.
+	Message string `json:"message,omitempty"`
+	// Details is a list of messages with additional error details.
+	Details []map[string]any `json:"details,omitempty"`
+}
+
+// File is a file uploaded to the API.
+type File struct {
+	// Name is the resource name of the file.
+	Name string `json:"name,omitempty"`
+	// DisplayName is a human readable name for the file.
+	DisplayName string `json:"displayName,omitempty"`
+	// MIMEType is the media type of the file.
+	MIMEType string `json:"mimeType,omitempty"`
+	// SizeBytes is the size of the file in bytes.
+	SizeBytes *int64 `json:"sizeBytes,omitempty"`
+	// Sha256Hash is the SHA-256 hash of the file contents, base64 encoded.
+	Sha256Hash string `json:"sha256Hash,omitempty"`
+	// URI is the URI of the file.
+	URI string `json:"uri,omitempty"`
+	// DownloadURI is the URI at which the file contents can be downloaded.
+	DownloadURI string `json:"downloadUri,omitempty"`
+	// State is the current processing state of the file.
+	State FileState `json:"state,omitempty"`
+	// Source is how the file was created.
+	Source FileSource `json:"source,omitempty"`
+	// VideoMetadata is metadata for a video file, populated after processing.
+	VideoMetadata map[string]any `json:"videoMetadata,omitempty"`
+	// Error is the error that occurred while processing the file, if any.
+	Error *FileStatus `json:"error,omitempty"`
+	// ExpirationTime is when the file will be deleted.
+	ExpirationTime time.Time `json:"expirationTime,omitempty"`
+	// CreateTime is when the file was created.
+	CreateTime time.Time `json:"createTime,omitempty"`
+	// UpdateTime is when the file was last updated.
+	UpdateTime time.Time `json:"updateTime,omitempty"`
+}
+
+// SlidingWindow configures a context window compression strategy that keeps
+// only the most recent tokens.
+type SlidingWindow struct {
+	// TargetTokens is the number of tokens the window is compressed down to.
+	TargetTokens *int64 `json:"targetTokens,omitempty"`
+}
+
+// ContextWindowCompressionConfig configures context window compression for
+// long running sessions.
+type ContextWindowCompressionConfig struct {
+	// TriggerTokens is the number of tokens that triggers compression.
+	TriggerTokens *int64 `json:"triggerTokens,omitempty"`
+	// SlidingWindow configures the compression strategy.
+	SlidingWindow *SlidingWindow `json:"slidingWindow,omitempty"`
+}
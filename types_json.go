@@ -0,0 +1,793 @@
+package genai
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"time"
+
+	"cloud.google.com/go/civil"
+)
+
+// protoDurationPattern matches the protobuf JSON encoding of a
+// google.protobuf.Duration: a possibly negative, possibly fractional number
+// of seconds followed by a literal "s", e.g. "10s" or "3.5s".
+var protoDurationPattern = regexp.MustCompile(`^(-?[0-9]+(?:\.[0-9]+)?)s$`)
+
+// formatProtoDuration renders d the way the API expects a
+// google.protobuf.Duration to be rendered: a (possibly fractional) number of
+// seconds followed by "s".
+func formatProtoDuration(d time.Duration) string {
+	seconds := d.Seconds()
+	if seconds == math.Trunc(seconds) {
+		return strconv.FormatInt(int64(seconds), 10) + "s"
+	}
+	return strconv.FormatFloat(seconds, 'f', -1, 64) + "s"
+}
+
+// parseProtoDuration parses the protobuf JSON encoding of a
+// google.protobuf.Duration.
+func parseProtoDuration(s string) (time.Duration, error) {
+	m := protoDurationPattern.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("genai: invalid duration %q: want a number of seconds followed by \"s\"", s)
+	}
+	seconds, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("genai: invalid duration %q: %w", s, err)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+func unmarshalInt64String(data json.RawMessage) (int64, error) {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
+
+// MarshalJSON implements a Schema's mapping to the Vertex AI / Gemini API
+// wire format, in which MaxLength, MinLength, MaxProperties, MinProperties,
+// MaxItems and MinItems are int64 values encoded as JSON strings.
+func (s Schema) MarshalJSON() ([]byte, error) {
+	m := map[string]any{}
+	if len(s.AnyOf) > 0 {
+		m["anyOf"] = s.AnyOf
+	}
+	if s.Default != nil {
+		m["default"] = s.Default
+	}
+	if s.Description != "" {
+		m["description"] = s.Description
+	}
+	if len(s.Enum) > 0 {
+		m["enum"] = s.Enum
+	}
+	if s.Example != nil {
+		m["example"] = s.Example
+	}
+	if s.Format != "" {
+		m["format"] = s.Format
+	}
+	if s.Items != nil {
+		m["items"] = s.Items
+	}
+	if s.MaxItems != nil {
+		m["maxItems"] = strconv.FormatInt(*s.MaxItems, 10)
+	}
+	if s.MaxLength != nil {
+		m["maxLength"] = strconv.FormatInt(*s.MaxLength, 10)
+	}
+	if s.MaxProperties != nil {
+		m["maxProperties"] = strconv.FormatInt(*s.MaxProperties, 10)
+	}
+	if s.Maximum != nil {
+		m["maximum"] = *s.Maximum
+	}
+	if s.MinItems != nil {
+		m["minItems"] = strconv.FormatInt(*s.MinItems, 10)
+	}
+	if s.MinLength != nil {
+		m["minLength"] = strconv.FormatInt(*s.MinLength, 10)
+	}
+	if s.MinProperties != nil {
+		m["minProperties"] = strconv.FormatInt(*s.MinProperties, 10)
+	}
+	if s.Minimum != nil {
+		m["minimum"] = *s.Minimum
+	}
+	if s.Nullable != nil {
+		m["nullable"] = *s.Nullable
+	}
+	if s.Pattern != "" {
+		m["pattern"] = s.Pattern
+	}
+	if len(s.Properties) > 0 {
+		m["properties"] = s.Properties
+	}
+	if len(s.PropertyOrdering) > 0 {
+		m["propertyOrdering"] = s.PropertyOrdering
+	}
+	if len(s.Required) > 0 {
+		m["required"] = s.Required
+	}
+	if s.Title != "" {
+		m["title"] = s.Title
+	}
+	if s.Type != "" {
+		m["type"] = s.Type
+	}
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON implements the inverse of Schema.MarshalJSON.
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	for k, v := range m {
+		var err error
+		switch k {
+		case "anyOf":
+			err = json.Unmarshal(v, &s.AnyOf)
+		case "default":
+			err = json.Unmarshal(v, &s.Default)
+		case "description":
+			err = json.Unmarshal(v, &s.Description)
+		case "enum":
+			err = json.Unmarshal(v, &s.Enum)
+		case "example":
+			err = json.Unmarshal(v, &s.Example)
+		case "format":
+			err = json.Unmarshal(v, &s.Format)
+		case "items":
+			err = json.Unmarshal(v, &s.Items)
+		case "maxItems":
+			var n int64
+			if n, err = unmarshalInt64String(v); err == nil {
+				s.MaxItems = &n
+			}
+		case "maxLength":
+			var n int64
+			if n, err = unmarshalInt64String(v); err == nil {
+				s.MaxLength = &n
+			}
+		case "maxProperties":
+			var n int64
+			if n, err = unmarshalInt64String(v); err == nil {
+				s.MaxProperties = &n
+			}
+		case "maximum":
+			err = json.Unmarshal(v, &s.Maximum)
+		case "minItems":
+			var n int64
+			if n, err = unmarshalInt64String(v); err == nil {
+				s.MinItems = &n
+			}
+		case "minLength":
+			var n int64
+			if n, err = unmarshalInt64String(v); err == nil {
+				s.MinLength = &n
+			}
+		case "minProperties":
+			var n int64
+			if n, err = unmarshalInt64String(v); err == nil {
+				s.MinProperties = &n
+			}
+		case "minimum":
+			err = json.Unmarshal(v, &s.Minimum)
+		case "nullable":
+			err = json.Unmarshal(v, &s.Nullable)
+		case "pattern":
+			err = json.Unmarshal(v, &s.Pattern)
+		case "properties":
+			err = json.Unmarshal(v, &s.Properties)
+		case "propertyOrdering":
+			err = json.Unmarshal(v, &s.PropertyOrdering)
+		case "required":
+			err = json.Unmarshal(v, &s.Required)
+		case "title":
+			err = json.Unmarshal(v, &s.Title)
+		case "type":
+			err = json.Unmarshal(v, &s.Type)
+		}
+		if err != nil {
+			return fmt.Errorf("genai: Schema.%s: %w", k, err)
+		}
+	}
+	return nil
+}
+
+type citationAlias Citation
+
+// MarshalJSON renders PublicationDate as a {"day", "month", "year"} object
+// rather than civil.Date's usual "YYYY-MM-DD" text encoding, to match the
+// API's wire format.
+func (c Citation) MarshalJSON() ([]byte, error) {
+	type publicationDate struct {
+		Day   int `json:"day,omitempty"`
+		Month int `json:"month,omitempty"`
+		Year  int `json:"year,omitempty"`
+	}
+	aux := struct {
+		PublicationDate *publicationDate `json:"publicationDate,omitempty"`
+		*citationAlias
+	}{
+		citationAlias: (*citationAlias)(&c),
+	}
+	if c.PublicationDate != (civil.Date{}) {
+		aux.PublicationDate = &publicationDate{
+			Day:   c.PublicationDate.Day,
+			Month: int(c.PublicationDate.Month),
+			Year:  c.PublicationDate.Year,
+		}
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON implements the inverse of Citation.MarshalJSON.
+func (c *Citation) UnmarshalJSON(data []byte) error {
+	type publicationDate struct {
+		Day   int `json:"day"`
+		Month int `json:"month"`
+		Year  int `json:"year"`
+	}
+	aux := struct {
+		PublicationDate *publicationDate `json:"publicationDate"`
+		*citationAlias
+	}{
+		citationAlias: (*citationAlias)(c),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if aux.PublicationDate != nil {
+		if aux.PublicationDate.Year == 0 {
+			return fmt.Errorf("genai: Citation.publicationDate.year is required")
+		}
+		c.PublicationDate = civil.Date{
+			Year:  aux.PublicationDate.Year,
+			Month: time.Month(aux.PublicationDate.Month),
+			Day:   aux.PublicationDate.Day,
+		}
+	}
+	return nil
+}
+
+type tokensInfoAlias TokensInfo
+
+// MarshalJSON renders TokenIDs as JSON strings, since they are int64 values.
+func (t TokensInfo) MarshalJSON() ([]byte, error) {
+	aux := struct {
+		TokenIDs []string `json:"tokenIds,omitempty"`
+		*tokensInfoAlias
+	}{
+		tokensInfoAlias: (*tokensInfoAlias)(&t),
+	}
+	if t.TokenIDs != nil {
+		aux.TokenIDs = make([]string, len(t.TokenIDs))
+		for i, id := range t.TokenIDs {
+			aux.TokenIDs[i] = strconv.FormatInt(id, 10)
+		}
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON implements the inverse of TokensInfo.MarshalJSON.
+func (t *TokensInfo) UnmarshalJSON(data []byte) error {
+	aux := struct {
+		TokenIDs []string `json:"tokenIds"`
+		*tokensInfoAlias
+	}{
+		tokensInfoAlias: (*tokensInfoAlias)(t),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if aux.TokenIDs != nil {
+		t.TokenIDs = make([]int64, len(aux.TokenIDs))
+		for i, s := range aux.TokenIDs {
+			n, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return fmt.Errorf("genai: TokensInfo.tokenIds[%d]: %w", i, err)
+			}
+			t.TokenIDs[i] = n
+		}
+	}
+	return nil
+}
+
+type createCachedContentConfigAlias CreateCachedContentConfig
+
+// MarshalJSON omits ExpireTime when it is the zero time.Time, rather than
+// rendering it as "0001-01-01T00:00:00Z".
+func (c CreateCachedContentConfig) MarshalJSON() ([]byte, error) {
+	aux := struct {
+		ExpireTime *time.Time `json:"expireTime,omitempty"`
+		*createCachedContentConfigAlias
+	}{
+		createCachedContentConfigAlias: (*createCachedContentConfigAlias)(&c),
+	}
+	if !c.ExpireTime.IsZero() {
+		aux.ExpireTime = &c.ExpireTime
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON implements the inverse of CreateCachedContentConfig.MarshalJSON.
+func (c *CreateCachedContentConfig) UnmarshalJSON(data []byte) error {
+	aux := struct {
+		ExpireTime *time.Time `json:"expireTime"`
+		*createCachedContentConfigAlias
+	}{
+		createCachedContentConfigAlias: (*createCachedContentConfigAlias)(c),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if aux.ExpireTime != nil {
+		c.ExpireTime = *aux.ExpireTime
+	}
+	return nil
+}
+
+type updateCachedContentConfigAlias UpdateCachedContentConfig
+
+// MarshalJSON omits ExpireTime when it is the zero time.Time.
+func (c UpdateCachedContentConfig) MarshalJSON() ([]byte, error) {
+	aux := struct {
+		ExpireTime *time.Time `json:"expireTime,omitempty"`
+		*updateCachedContentConfigAlias
+	}{
+		updateCachedContentConfigAlias: (*updateCachedContentConfigAlias)(&c),
+	}
+	if !c.ExpireTime.IsZero() {
+		aux.ExpireTime = &c.ExpireTime
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON implements the inverse of UpdateCachedContentConfig.MarshalJSON.
+func (c *UpdateCachedContentConfig) UnmarshalJSON(data []byte) error {
+	aux := struct {
+		ExpireTime *time.Time `json:"expireTime"`
+		*updateCachedContentConfigAlias
+	}{
+		updateCachedContentConfigAlias: (*updateCachedContentConfigAlias)(c),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if aux.ExpireTime != nil {
+		c.ExpireTime = *aux.ExpireTime
+	}
+	return nil
+}
+
+type generateContentResponseAlias GenerateContentResponse
+
+// MarshalJSON omits CreateTime when it is the zero time.Time.
+func (g GenerateContentResponse) MarshalJSON() ([]byte, error) {
+	aux := struct {
+		CreateTime *time.Time `json:"createTime,omitempty"`
+		*generateContentResponseAlias
+	}{
+		generateContentResponseAlias: (*generateContentResponseAlias)(&g),
+	}
+	if !g.CreateTime.IsZero() {
+		aux.CreateTime = &g.CreateTime
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON implements the inverse of GenerateContentResponse.MarshalJSON.
+func (g *GenerateContentResponse) UnmarshalJSON(data []byte) error {
+	aux := struct {
+		CreateTime *time.Time `json:"createTime"`
+		*generateContentResponseAlias
+	}{
+		generateContentResponseAlias: (*generateContentResponseAlias)(g),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if aux.CreateTime != nil {
+		g.CreateTime = *aux.CreateTime
+	}
+	return nil
+}
+
+type tunedModelInfoAlias TunedModelInfo
+
+// MarshalJSON omits CreateTime and UpdateTime when they are the zero
+// time.Time.
+func (t TunedModelInfo) MarshalJSON() ([]byte, error) {
+	aux := struct {
+		CreateTime *time.Time `json:"createTime,omitempty"`
+		UpdateTime *time.Time `json:"updateTime,omitempty"`
+		*tunedModelInfoAlias
+	}{
+		tunedModelInfoAlias: (*tunedModelInfoAlias)(&t),
+	}
+	if !t.CreateTime.IsZero() {
+		aux.CreateTime = &t.CreateTime
+	}
+	if !t.UpdateTime.IsZero() {
+		aux.UpdateTime = &t.UpdateTime
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON implements the inverse of TunedModelInfo.MarshalJSON.
+func (t *TunedModelInfo) UnmarshalJSON(data []byte) error {
+	aux := struct {
+		CreateTime *time.Time `json:"createTime"`
+		UpdateTime *time.Time `json:"updateTime"`
+		*tunedModelInfoAlias
+	}{
+		tunedModelInfoAlias: (*tunedModelInfoAlias)(t),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if aux.CreateTime != nil {
+		t.CreateTime = *aux.CreateTime
+	}
+	if aux.UpdateTime != nil {
+		t.UpdateTime = *aux.UpdateTime
+	}
+	return nil
+}
+
+type cachedContentAlias CachedContent
+
+// MarshalJSON omits CreateTime, UpdateTime and ExpireTime when they are the
+// zero time.Time.
+func (c CachedContent) MarshalJSON() ([]byte, error) {
+	aux := struct {
+		CreateTime *time.Time `json:"createTime,omitempty"`
+		UpdateTime *time.Time `json:"updateTime,omitempty"`
+		ExpireTime *time.Time `json:"expireTime,omitempty"`
+		*cachedContentAlias
+	}{
+		cachedContentAlias: (*cachedContentAlias)(&c),
+	}
+	if !c.CreateTime.IsZero() {
+		aux.CreateTime = &c.CreateTime
+	}
+	if !c.UpdateTime.IsZero() {
+		aux.UpdateTime = &c.UpdateTime
+	}
+	if !c.ExpireTime.IsZero() {
+		aux.ExpireTime = &c.ExpireTime
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON implements the inverse of CachedContent.MarshalJSON.
+func (c *CachedContent) UnmarshalJSON(data []byte) error {
+	aux := struct {
+		CreateTime *time.Time `json:"createTime"`
+		UpdateTime *time.Time `json:"updateTime"`
+		ExpireTime *time.Time `json:"expireTime"`
+		*cachedContentAlias
+	}{
+		cachedContentAlias: (*cachedContentAlias)(c),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if aux.CreateTime != nil {
+		c.CreateTime = *aux.CreateTime
+	}
+	if aux.UpdateTime != nil {
+		c.UpdateTime = *aux.UpdateTime
+	}
+	if aux.ExpireTime != nil {
+		c.ExpireTime = *aux.ExpireTime
+	}
+	return nil
+}
+
+// MarshalJSON renders StartOffset and EndOffset using the style selected by
+// VideoMetadataMarshalStyle (the protobuf JSON Duration encoding, e.g.
+// "10s", by default) rather than time.Duration's default nanosecond integer
+// encoding. StartOffset is included, even when zero, whenever EndOffset is
+// set, since the API treats an EndOffset without a StartOffset as starting
+// from 0.
+func (v VideoMetadata) MarshalJSON() ([]byte, error) {
+	aux := struct {
+		EndOffset   *string `json:"endOffset,omitempty"`
+		StartOffset *string `json:"startOffset,omitempty"`
+	}{}
+	if v.EndOffset != 0 {
+		end := formatVideoOffset(v.EndOffset)
+		aux.EndOffset = &end
+		start := formatVideoOffset(v.StartOffset)
+		aux.StartOffset = &start
+	} else if v.StartOffset != 0 {
+		start := formatVideoOffset(v.StartOffset)
+		aux.StartOffset = &start
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON implements the inverse of VideoMetadata.MarshalJSON. Beyond
+// the protobuf JSON Duration form it writes, it also accepts an HH:MM:SS or
+// HH:MM:SS.fff timecode, an ISO 8601 duration ("PT1H30M"), or a plain JSON
+// number of seconds, so that offsets from ffprobe, WebVTT cues, or manifest
+// files can be fed in directly. See ParseVideoOffset.
+func (v *VideoMetadata) UnmarshalJSON(data []byte) error {
+	aux := struct {
+		StartOffset json.RawMessage `json:"startOffset"`
+		EndOffset   json.RawMessage `json:"endOffset"`
+	}{}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if len(aux.StartOffset) > 0 {
+		d, err := unmarshalVideoOffset(aux.StartOffset)
+		if err != nil {
+			return fmt.Errorf("genai: VideoMetadata.startOffset: %w", err)
+		}
+		v.StartOffset = d
+	}
+	if len(aux.EndOffset) > 0 {
+		d, err := unmarshalVideoOffset(aux.EndOffset)
+		if err != nil {
+			return fmt.Errorf("genai: VideoMetadata.endOffset: %w", err)
+		}
+		v.EndOffset = d
+	}
+	return nil
+}
+
+// unmarshalVideoOffset parses a startOffset/endOffset value that may be a
+// JSON string (in any format ParseVideoOffset accepts) or a JSON number of
+// seconds.
+func unmarshalVideoOffset(raw json.RawMessage) (time.Duration, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		if s == "" {
+			return 0, nil
+		}
+		return ParseVideoOffset(s)
+	}
+	var seconds float64
+	if err := json.Unmarshal(raw, &seconds); err == nil {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	return 0, fmt.Errorf("must be a string or a number, got %s", raw)
+}
+
+type checkpointAlias Checkpoint
+
+// MarshalJSON renders Epoch and Step as JSON strings, since they are int64
+// values.
+func (c Checkpoint) MarshalJSON() ([]byte, error) {
+	aux := struct {
+		*checkpointAlias
+		Epoch *string `json:"epoch,omitempty"`
+		Step  *string `json:"step,omitempty"`
+	}{
+		checkpointAlias: (*checkpointAlias)(&c),
+	}
+	if c.Epoch != 0 {
+		epoch := strconv.FormatInt(c.Epoch, 10)
+		aux.Epoch = &epoch
+	}
+	if c.Step != 0 {
+		step := strconv.FormatInt(c.Step, 10)
+		aux.Step = &step
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON implements the inverse of Checkpoint.MarshalJSON.
+func (c *Checkpoint) UnmarshalJSON(data []byte) error {
+	aux := struct {
+		*checkpointAlias
+		Epoch *string `json:"epoch"`
+		Step  *string `json:"step"`
+	}{
+		checkpointAlias: (*checkpointAlias)(c),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if aux.Epoch != nil {
+		n, err := strconv.ParseInt(*aux.Epoch, 10, 64)
+		if err != nil {
+			return fmt.Errorf("genai: Checkpoint.epoch: %w", err)
+		}
+		c.Epoch = n
+	}
+	if aux.Step != nil {
+		n, err := strconv.ParseInt(*aux.Step, 10, 64)
+		if err != nil {
+			return fmt.Errorf("genai: Checkpoint.step: %w", err)
+		}
+		c.Step = n
+	}
+	return nil
+}
+
+type slidingWindowAlias SlidingWindow
+
+// MarshalJSON renders TargetTokens as a JSON string, since it is an int64
+// value.
+func (s SlidingWindow) MarshalJSON() ([]byte, error) {
+	aux := struct {
+		TargetTokens *string `json:"targetTokens,omitempty"`
+		*slidingWindowAlias
+	}{
+		slidingWindowAlias: (*slidingWindowAlias)(&s),
+	}
+	if s.TargetTokens != nil {
+		targetTokens := strconv.FormatInt(*s.TargetTokens, 10)
+		aux.TargetTokens = &targetTokens
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON implements the inverse of SlidingWindow.MarshalJSON.
+func (s *SlidingWindow) UnmarshalJSON(data []byte) error {
+	aux := struct {
+		TargetTokens *string `json:"targetTokens"`
+		*slidingWindowAlias
+	}{
+		slidingWindowAlias: (*slidingWindowAlias)(s),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if aux.TargetTokens != nil {
+		n, err := strconv.ParseInt(*aux.TargetTokens, 10, 64)
+		if err != nil {
+			return fmt.Errorf("genai: SlidingWindow.targetTokens: %w", err)
+		}
+		s.TargetTokens = &n
+	}
+	return nil
+}
+
+type contextWindowCompressionConfigAlias ContextWindowCompressionConfig
+
+// MarshalJSON renders TriggerTokens as a JSON string, since it is an int64
+// value.
+func (c ContextWindowCompressionConfig) MarshalJSON() ([]byte, error) {
+	aux := struct {
+		TriggerTokens *string `json:"triggerTokens,omitempty"`
+		*contextWindowCompressionConfigAlias
+	}{
+		contextWindowCompressionConfigAlias: (*contextWindowCompressionConfigAlias)(&c),
+	}
+	if c.TriggerTokens != nil {
+		triggerTokens := strconv.FormatInt(*c.TriggerTokens, 10)
+		aux.TriggerTokens = &triggerTokens
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON implements the inverse of
+// ContextWindowCompressionConfig.MarshalJSON.
+func (c *ContextWindowCompressionConfig) UnmarshalJSON(data []byte) error {
+	aux := struct {
+		TriggerTokens *string `json:"triggerTokens"`
+		*contextWindowCompressionConfigAlias
+	}{
+		contextWindowCompressionConfigAlias: (*contextWindowCompressionConfigAlias)(c),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if aux.TriggerTokens != nil {
+		n, err := strconv.ParseInt(*aux.TriggerTokens, 10, 64)
+		if err != nil {
+			return fmt.Errorf("genai: ContextWindowCompressionConfig.triggerTokens: %w", err)
+		}
+		c.TriggerTokens = &n
+	}
+	return nil
+}
+
+// MarshalJSON renders SizeBytes as a JSON string, since it is an int64
+// value, and omits ExpirationTime, CreateTime and UpdateTime when they are
+// the zero time.Time.
+func (f File) MarshalJSON() ([]byte, error) {
+	aux := struct {
+		Name           string         `json:"name,omitempty"`
+		DisplayName    string         `json:"displayName,omitempty"`
+		MIMEType       string         `json:"mimeType,omitempty"`
+		SizeBytes      *string        `json:"sizeBytes,omitempty"`
+		Sha256Hash     string         `json:"sha256Hash,omitempty"`
+		URI            string         `json:"uri,omitempty"`
+		DownloadURI    string         `json:"downloadUri,omitempty"`
+		State          FileState      `json:"state,omitempty"`
+		Source         FileSource     `json:"source,omitempty"`
+		VideoMetadata  map[string]any `json:"videoMetadata,omitempty"`
+		Error          *FileStatus    `json:"error,omitempty"`
+		ExpirationTime *time.Time     `json:"expirationTime,omitempty"`
+		CreateTime     *time.Time     `json:"createTime,omitempty"`
+		UpdateTime     *time.Time     `json:"updateTime,omitempty"`
+	}{
+		Name:          f.Name,
+		DisplayName:   f.DisplayName,
+		MIMEType:      f.MIMEType,
+		Sha256Hash:    f.Sha256Hash,
+		URI:           f.URI,
+		DownloadURI:   f.DownloadURI,
+		State:         f.State,
+		Source:        f.Source,
+		VideoMetadata: f.VideoMetadata,
+		Error:         f.Error,
+	}
+	if f.SizeBytes != nil {
+		sizeBytes := strconv.FormatInt(*f.SizeBytes, 10)
+		aux.SizeBytes = &sizeBytes
+	}
+	if !f.ExpirationTime.IsZero() {
+		aux.ExpirationTime = &f.ExpirationTime
+	}
+	if !f.CreateTime.IsZero() {
+		aux.CreateTime = &f.CreateTime
+	}
+	if !f.UpdateTime.IsZero() {
+		aux.UpdateTime = &f.UpdateTime
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON implements the inverse of File.MarshalJSON.
+func (f *File) UnmarshalJSON(data []byte) error {
+	aux := struct {
+		Name           string         `json:"name"`
+		DisplayName    string         `json:"displayName"`
+		MIMEType       string         `json:"mimeType"`
+		SizeBytes      *string        `json:"sizeBytes"`
+		Sha256Hash     string         `json:"sha256Hash"`
+		URI            string         `json:"uri"`
+		DownloadURI    string         `json:"downloadUri"`
+		State          FileState      `json:"state"`
+		Source         FileSource     `json:"source"`
+		VideoMetadata  map[string]any `json:"videoMetadata"`
+		Error          *FileStatus    `json:"error"`
+		ExpirationTime *time.Time     `json:"expirationTime"`
+		CreateTime     *time.Time     `json:"createTime"`
+		UpdateTime     *time.Time     `json:"updateTime"`
+	}{}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	f.Name = aux.Name
+	f.DisplayName = aux.DisplayName
+	f.MIMEType = aux.MIMEType
+	f.Sha256Hash = aux.Sha256Hash
+	f.URI = aux.URI
+	f.DownloadURI = aux.DownloadURI
+	f.State = aux.State
+	f.Source = aux.Source
+	f.VideoMetadata = aux.VideoMetadata
+	f.Error = aux.Error
+	if aux.SizeBytes != nil {
+		n, err := strconv.ParseInt(*aux.SizeBytes, 10, 64)
+		if err != nil {
+			return fmt.Errorf("genai: File.sizeBytes: %w", err)
+		}
+		f.SizeBytes = &n
+	}
+	if aux.ExpirationTime != nil {
+		f.ExpirationTime = *aux.ExpirationTime
+	}
+	if aux.CreateTime != nil {
+		f.CreateTime = *aux.CreateTime
+	}
+	if aux.UpdateTime != nil {
+		f.UpdateTime = *aux.UpdateTime
+	}
+	return nil
+}
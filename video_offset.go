@@ -0,0 +1,164 @@
+package genai
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// videoOffsetStyle is the wire format VideoMetadata's MarshalJSON uses for
+// StartOffset and EndOffset. It is unexported so that the package-level
+// VideoMetadataMarshalStyle variable can share its name with this type's
+// concept without colliding with it; callers only ever need the exported
+// VideoMetadataMarshalStyle* constants, never the type name itself.
+type videoOffsetStyle string
+
+const (
+	// VideoMetadataMarshalStyleProtoDuration renders offsets using the
+	// protobuf JSON Duration encoding (e.g. "10s"). This is the default,
+	// matching the Gemini API's own wire format.
+	VideoMetadataMarshalStyleProtoDuration videoOffsetStyle = "proto_duration"
+	// VideoMetadataMarshalStyleTimecode renders offsets as HH:MM:SS.fff
+	// timecodes (e.g. "00:00:10.000").
+	VideoMetadataMarshalStyleTimecode videoOffsetStyle = "timecode"
+	// VideoMetadataMarshalStyleISO8601 renders offsets as ISO 8601
+	// durations (e.g. "PT10S").
+	VideoMetadataMarshalStyleISO8601 videoOffsetStyle = "iso8601"
+)
+
+// VideoMetadataMarshalStyle controls the format VideoMetadata.MarshalJSON
+// writes StartOffset and EndOffset in. It defaults to
+// VideoMetadataMarshalStyleProtoDuration to preserve the Gemini API's wire
+// format; UnmarshalJSON always accepts all supported formats regardless of
+// this setting.
+var VideoMetadataMarshalStyle = VideoMetadataMarshalStyleProtoDuration
+
+var (
+	timecodePattern        = regexp.MustCompile(`^(\d+):([0-5]?\d):([0-5]?\d)(\.\d+)?$`)
+	iso8601DurationPattern = regexp.MustCompile(`^PT(?:(\d+(?:\.\d+)?)H)?(?:(\d+(?:\.\d+)?)M)?(?:(\d+(?:\.\d+)?)S)?$`)
+)
+
+// ParseVideoOffset parses a video offset in any of the formats VideoMetadata
+// accepts on unmarshal: the protobuf JSON Duration form ("10s", "72000s"),
+// an HH:MM:SS or HH:MM:SS.fff timecode, an ISO 8601 duration ("PT1H30M"), or
+// a plain number of seconds. It is exported for callers building Parts from
+// subtitle, manifest, or ffprobe output, which commonly use one of these
+// non-protobuf forms.
+func ParseVideoOffset(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("genai: ParseVideoOffset: empty string")
+	}
+	switch {
+	case strings.HasPrefix(s, "PT"):
+		return parseISO8601Duration(s)
+	case protoDurationPattern.MatchString(s):
+		return parseProtoDuration(s)
+	case strings.Contains(s, ":"):
+		return parseVideoTimecode(s)
+	}
+	seconds, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("genai: ParseVideoOffset: unrecognized duration %q", s)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+func parseVideoTimecode(s string) (time.Duration, error) {
+	m := timecodePattern.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("genai: invalid HH:MM:SS timecode %q", s)
+	}
+	hours, _ := strconv.Atoi(m[1])
+	minutes, _ := strconv.Atoi(m[2])
+	seconds, _ := strconv.Atoi(m[3])
+	d := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	if m[4] != "" {
+		frac, err := strconv.ParseFloat(m[4], 64)
+		if err != nil {
+			return 0, fmt.Errorf("genai: invalid HH:MM:SS timecode %q: %w", s, err)
+		}
+		d += time.Duration(frac * float64(time.Second))
+	}
+	return d, nil
+}
+
+func parseISO8601Duration(s string) (time.Duration, error) {
+	m := iso8601DurationPattern.FindStringSubmatch(s)
+	if m == nil || (m[1] == "" && m[2] == "" && m[3] == "") {
+		return 0, fmt.Errorf("genai: invalid ISO 8601 duration %q", s)
+	}
+	var d time.Duration
+	for _, part := range []struct {
+		value string
+		unit  time.Duration
+	}{
+		{m[1], time.Hour},
+		{m[2], time.Minute},
+		{m[3], time.Second},
+	} {
+		if part.value == "" {
+			continue
+		}
+		n, err := strconv.ParseFloat(part.value, 64)
+		if err != nil {
+			return 0, fmt.Errorf("genai: invalid ISO 8601 duration %q: %w", s, err)
+		}
+		d += time.Duration(n * float64(part.unit))
+	}
+	return d, nil
+}
+
+func formatVideoTimecode(d time.Duration) string {
+	total := d.Milliseconds()
+	hours := total / (3600 * 1000)
+	total -= hours * 3600 * 1000
+	minutes := total / (60 * 1000)
+	total -= minutes * 60 * 1000
+	seconds := total / 1000
+	millis := total - seconds*1000
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", hours, minutes, seconds, millis)
+}
+
+func formatISO8601Duration(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+	hours := d / time.Hour
+	d -= hours * time.Hour
+	minutes := d / time.Minute
+	d -= minutes * time.Minute
+	seconds := d.Seconds()
+
+	var b strings.Builder
+	b.WriteString("PT")
+	if hours > 0 {
+		fmt.Fprintf(&b, "%dH", hours)
+	}
+	if minutes > 0 {
+		fmt.Fprintf(&b, "%dM", minutes)
+	}
+	if seconds > 0 {
+		if seconds == float64(int64(seconds)) {
+			fmt.Fprintf(&b, "%dS", int64(seconds))
+		} else {
+			fmt.Fprintf(&b, "%gS", seconds)
+		}
+	}
+	return b.String()
+}
+
+// formatVideoOffset renders d using the style selected by
+// VideoMetadataMarshalStyle.
+func formatVideoOffset(d time.Duration) string {
+	switch VideoMetadataMarshalStyle {
+	case VideoMetadataMarshalStyleTimecode:
+		return formatVideoTimecode(d)
+	case VideoMetadataMarshalStyleISO8601:
+		return formatISO8601Duration(d)
+	default:
+		return formatProtoDuration(d)
+	}
+}
@@ -0,0 +1,100 @@
+package genai
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseVideoOffset(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"10s", 10 * time.Second},
+		{"72000s", 72000 * time.Second},
+		{"00:00:10", 10 * time.Second},
+		{"01:02:03.5", time.Hour + 2*time.Minute + 3*time.Second + 500*time.Millisecond},
+		{"PT1H30M", time.Hour + 30*time.Minute},
+		{"PT10S", 10 * time.Second},
+		{"PT0S", 0},
+		{"90", 90 * time.Second},
+		{"1.5", 1500 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		got, err := ParseVideoOffset(tt.in)
+		if err != nil {
+			t.Errorf("ParseVideoOffset(%q) = error %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseVideoOffset(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseVideoOffsetInvalid(t *testing.T) {
+	for _, in := range []string{"", "abc", "PT", "25:99:00", "10x"} {
+		if _, err := ParseVideoOffset(in); err == nil {
+			t.Errorf("ParseVideoOffset(%q) = nil error, want error", in)
+		}
+	}
+}
+
+func TestVideoMetadataUnmarshalJSONFormats(t *testing.T) {
+	tests := []struct {
+		name    string
+		jsonStr string
+		want    time.Duration
+	}{
+		{"timecode", `{"startOffset": "00:01:30.000"}`, 90 * time.Second},
+		{"iso8601", `{"startOffset": "PT1M30S"}`, 90 * time.Second},
+		{"number", `{"startOffset": 90}`, 90 * time.Second},
+		{"proto duration", `{"startOffset": "90s"}`, 90 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v VideoMetadata
+			if err := json.Unmarshal([]byte(tt.jsonStr), &v); err != nil {
+				t.Fatal(err)
+			}
+			if v.StartOffset != tt.want {
+				t.Errorf("StartOffset = %v, want %v", v.StartOffset, tt.want)
+			}
+		})
+	}
+}
+
+func TestVideoMetadataMarshalJSONStyles(t *testing.T) {
+	orig := VideoMetadataMarshalStyle
+	defer func() { VideoMetadataMarshalStyle = orig }()
+
+	v := VideoMetadata{StartOffset: 90 * time.Second}
+
+	VideoMetadataMarshalStyle = VideoMetadataMarshalStyleProtoDuration
+	got, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"startOffset":"90s"}`; string(got) != want {
+		t.Errorf("proto duration style: got %s, want %s", got, want)
+	}
+
+	VideoMetadataMarshalStyle = VideoMetadataMarshalStyleTimecode
+	got, err = json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"startOffset":"00:01:30.000"}`; string(got) != want {
+		t.Errorf("timecode style: got %s, want %s", got, want)
+	}
+
+	VideoMetadataMarshalStyle = VideoMetadataMarshalStyleISO8601
+	got, err = json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"startOffset":"PT1M30S"}`; string(got) != want {
+		t.Errorf("iso8601 style: got %s, want %s", got, want)
+	}
+}